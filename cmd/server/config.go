@@ -0,0 +1,107 @@
+/**
+ * @file Server configuration
+ * @description
+ * Defines the Config struct used to boot the CodeSync web server and loads it
+ * from environment variables with sane defaults. Keeping this separate from
+ * main.go lets run() be exercised in tests without touching process globals
+ * beyond os.Getenv.
+ *
+ * @notes
+ * - Durations are expressed in seconds via env vars (e.g. READ_TIMEOUT=15)
+ * - A missing or invalid env var falls back to its default rather than
+ *   failing startup
+ * - AssetsDir defaults to the on-disk internal/assets directory outside of
+ *   production, so live-reload and template editing work without an
+ *   engineer having to set ASSETS_DIR themselves
+ */
+
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/brettsmith212/CodeSync/internal/assets"
+)
+
+// Config holds everything needed to boot the server.
+type Config struct {
+	Port            string
+	AppEnv          string
+	AssetsDir       string
+	ExportRoot      string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// Production reports whether the server is configured to run in production
+// mode (APP_ENV=production).
+func (c *Config) Production() bool {
+	return c.AppEnv == "production"
+}
+
+// AssetFilesystems returns the templates and public filesystems to serve
+// from. With an empty AssetsDir these are the binary's embedded assets; if
+// AssetsDir is set (via ASSETS_DIR, -assets-dir, or loadConfig's development
+// default), it's used instead via os.DirFS so edits on disk are picked up
+// without a rebuild.
+func (c *Config) AssetFilesystems() (templatesFS, publicFS fs.FS) {
+	if c.AssetsDir == "" {
+		return assets.Templates, assets.Public
+	}
+	return os.DirFS(filepath.Join(c.AssetsDir, "templates")), os.DirFS(filepath.Join(c.AssetsDir, "public"))
+}
+
+// devAssetsDir is where templates/ and public/ live on disk, relative to the
+// repo root. It's the default AssetsDir in development so live-reload and
+// template editing work out of the box, without every engineer having to
+// remember to set ASSETS_DIR.
+const devAssetsDir = "internal/assets"
+
+// loadConfig builds a Config from environment variables, falling back to
+// defaults for anything unset or invalid.
+func loadConfig() *Config {
+	appEnv := getEnv("APP_ENV", "development")
+
+	assetsDirDefault := ""
+	if appEnv != "production" {
+		assetsDirDefault = devAssetsDir
+	}
+
+	return &Config{
+		Port:            getEnv("PORT", "8080"),
+		AppEnv:          appEnv,
+		AssetsDir:       getEnv("ASSETS_DIR", assetsDirDefault),
+		ExportRoot:      getEnv("EXPORT_ROOT", "."),
+		ReadTimeout:     getEnvDuration("READ_TIMEOUT", 5*time.Second),
+		WriteTimeout:    getEnvDuration("WRITE_TIMEOUT", 10*time.Second),
+		IdleTimeout:     getEnvDuration("IDLE_TIMEOUT", 120*time.Second),
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// getEnvDuration reads an env var as whole seconds, returning fallback if the
+// var is unset or not a valid integer.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -5,10 +5,16 @@
  * loads environment variables, registers routes, and serves static files and templates.
  * It acts as the central hub for starting the application.
  *
+ * main() is kept intentionally thin: it loads config and env, then delegates to
+ * run(), which builds the router, template cache, and *http.Server and blocks
+ * until the server is asked to shut down. Splitting it this way lets run() be
+ * exercised directly in tests against an ephemeral listener.
+ *
  * Key features:
  * - Environment variable loading via godotenv
  * - Basic route handling with HTMX and Go templates
  * - Static file serving from the public directory
+ * - Graceful shutdown on SIGINT/SIGTERM that drains in-flight requests
  *
  * @dependencies
  * - github.com/go-chi/chi/v5: HTTP router
@@ -24,66 +30,179 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"html/template"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
+
+	"github.com/brettsmith212/CodeSync/internal/assets"
+	"github.com/brettsmith212/CodeSync/internal/devreload"
+	"github.com/brettsmith212/CodeSync/internal/handlers/xmlexport"
+	"github.com/brettsmith212/CodeSync/internal/middleware/security"
+	"github.com/brettsmith212/CodeSync/internal/render"
+	"github.com/brettsmith212/CodeSync/internal/session"
 )
 
+// devReloadDebounce coalesces bursts of filesystem events (e.g. an editor
+// saving several files at once) into a single reload.
+const devReloadDebounce = 150 * time.Millisecond
+
 func main() {
-	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	// Load environment variables from .env file. Its absence is common in
+	// production (env is injected another way), so it's a warning, not fatal.
+	if err := godotenv.Load(); err != nil {
+		log.Printf("warning: .env file not loaded: %v", err)
+	}
+
+	cfg := loadConfig()
+
+	assetsDir := flag.String("assets-dir", cfg.AssetsDir, "serve templates/public from this directory instead of the embedded assets")
+	flag.Parse()
+	cfg.AssetsDir = *assetsDir
+
+	ctx := context.Background()
+	if err := run(ctx, cfg, nil); err != nil {
+		log.Fatal(err)
 	}
+}
+
+// run builds the router and *http.Server from cfg and blocks until ctx is
+// cancelled (e.g. by SIGINT/SIGTERM), at which point it performs a graceful
+// shutdown, or until the server fails, in which case it returns the serve
+// error without a shutdown. If ln is non-nil it is used instead
+// of binding cfg.Port, which lets tests boot the server on an ephemeral port.
+func run(ctx context.Context, cfg *Config, ln net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Initialize router
 	r := chi.NewRouter()
 
 	// Middleware for logging, recovery, and request context
-	r.Use(middleware.Logger)      // Logs incoming requests
-	r.Use(middleware.Recoverer)   // Recovers from panics
-	r.Use(middleware.RequestID)   // Adds a unique ID to each request
-	r.Use(middleware.RealIP)      // Ensures real client IP is captured
+	r.Use(middleware.Logger)    // Logs incoming requests
+	r.Use(middleware.Recoverer) // Recovers from panics
+	r.Use(middleware.RequestID) // Adds a unique ID to each request
+
+	// CSP and other security headers, with a fresh nonce issued per request
+	securityCfg := security.DefaultConfig()
+	if cfg.Production() {
+		securityCfg.HSTSMaxAgeSeconds = 63072000 // 2 years
+	}
+	r.Use(security.New(securityCfg))
+
+	r.Use(middleware.RealIP) // Ensures real client IP is captured
+
+	// Session manager backs the clipboard file selection across requests
+	sessionManager := session.New(nil)
+	r.Use(sessionManager.LoadAndSave)
 
-	// Load all templates from internal/templates directory
-	tmpl, err := template.ParseGlob("internal/templates/**/*.html")
+	// Templates and static assets come from the binary's embedded copy when
+	// AssetsDir is empty (the production default); otherwise AssetsDir is
+	// served with a plain os.DirFS for live editing
+	templatesFS, publicFS := cfg.AssetFilesystems()
+
+	publicAssets, err := assets.NewPublicAssets(publicFS)
 	if err != nil {
-		log.Fatal("Error loading templates:", err)
+		return fmt.Errorf("indexing public assets: %w", err)
+	}
+
+	// devReloadScript is always registered so base.html parses in every mode;
+	// it's only non-empty when dev live-reload is actually running
+	funcMap := template.FuncMap{
+		"asset":           publicAssets.URL,
+		"devReloadScript": func(nonce string) template.HTML { return "" },
+	}
+
+	if !cfg.Production() && cfg.AssetsDir != "" {
+		reloader := devreload.New()
+		watchDirs := []string{
+			filepath.Join(cfg.AssetsDir, "templates"),
+			filepath.Join(cfg.AssetsDir, "public"),
+		}
+		if err := reloader.Watch(ctx, devReloadDebounce, watchDirs...); err != nil {
+			return fmt.Errorf("starting dev reload watcher: %w", err)
+		}
+		funcMap["devReloadScript"] = devreload.Script
+		r.Get("/_dev/reload", reloader.ServeSSE)
+	}
+
+	// Build the page template cache: parsed once up front in production,
+	// rebuilt on every request in development so edits show up live
+	if _, err := render.NewAppConfig(templatesFS, funcMap, cfg.Production()); err != nil {
+		return fmt.Errorf("loading templates: %w", err)
 	}
 
 	// Define root route
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-		// Data for the template
 		data := map[string]interface{}{
-			"Title": "Home",
+			"Title":    "Home",
+			"CSPNonce": security.Nonce(r),
 		}
-		// Render the base template with the data
-		if err := tmpl.ExecuteTemplate(w, "base", data); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+		if err := render.Page(w, r, "home", data); err != nil {
+			render.ServerError(w, r, err)
 		}
 	})
 
-	// TODO: Add routes for file handlers, clipboard handlers, and XML handlers
+	// XML export handler streams the session's selected files as an LLM-ready
+	// codebase bundle
+	xmlHandler := xmlexport.NewHandler(os.DirFS(cfg.ExportRoot))
+	r.Post("/export/xml", xmlHandler.Export)
+	r.Get("/export/xml/preview", xmlHandler.Preview)
+
+	// TODO: Add routes for file and clipboard handlers
 
 	// Serve static files from the public directory
-	fileServer := http.FileServer(http.Dir("public"))
-	r.Handle("/static/*", http.StripPrefix("/static/", fileServer))
+	r.Handle("/static/*", http.StripPrefix("/static/", publicAssets.FileServer()))
 
-	// Get port from environment variable or default to 8080
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	if ln == nil {
+		ln, err = net.Listen("tcp", ":"+cfg.Port)
+		if err != nil {
+			return fmt.Errorf("listening on port %s: %w", cfg.Port, err)
+		}
 	}
 
-	// Start the server
-	log.Printf("Server starting on port %s\n", port)
-	if err := http.ListenAndServe(":"+port, r); err != nil {
-		log.Fatal(err)
+	srv := &http.Server{
+		Handler:      r,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on %s\n", ln.Addr())
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+		log.Println("shutdown signal received, draining in-flight requests...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown: %w", err)
 	}
-}
\ No newline at end of file
+	return <-serveErr
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testConfig writes a minimal template/public tree to a temp dir and returns
+// a Config pointing at it, so run() doesn't depend on the real project layout.
+func testConfig(t *testing.T) *Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	templatesDir := filepath.Join(dir, "templates")
+	publicDir := filepath.Join(dir, "public")
+	pagesDir := filepath.Join(templatesDir, "pages")
+	layoutsDir := filepath.Join(templatesDir, "layouts")
+
+	for _, d := range []string{pagesDir, layoutsDir, publicDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", d, err)
+		}
+	}
+
+	base := `{{define "base"}}<html><body>{{block "content" .}}{{end}}</body></html>{{end}}`
+	if err := os.WriteFile(filepath.Join(layoutsDir, "base.html"), []byte(base), 0o644); err != nil {
+		t.Fatalf("write base.html: %v", err)
+	}
+
+	home := `{{define "content"}}{{.Title}}{{end}}`
+	if err := os.WriteFile(filepath.Join(pagesDir, "home.html"), []byte(home), 0o644); err != nil {
+		t.Fatalf("write home.html: %v", err)
+	}
+
+	return &Config{
+		AppEnv:          "development",
+		AssetsDir:       dir,
+		ReadTimeout:     5 * time.Second,
+		WriteTimeout:    5 * time.Second,
+		IdleTimeout:     30 * time.Second,
+		ShutdownTimeout: 2 * time.Second,
+	}
+}
+
+func TestRunServesRootAndShutsDownCleanly(t *testing.T) {
+	cfg := testConfig(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- run(ctx, cfg, ln)
+	}()
+
+	url := "http://" + ln.Addr().String() + "/"
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get(url)
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if got := string(body); got == "" {
+		t.Fatalf("expected non-empty body, got %q", got)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	cancel()
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Fatalf("run returned error after shutdown: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("run did not shut down in time")
+	}
+}
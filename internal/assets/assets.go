@@ -0,0 +1,43 @@
+/**
+ * @file Embedded template and static asset filesystems
+ * @description
+ * Embeds internal/assets/templates and internal/assets/public into the
+ * binary via go:embed, so the server ships as a single executable and can't
+ * break due to a missing working directory or a relative path assumption.
+ *
+ * @notes
+ * - Templates and Public are already rooted at their respective directories
+ *   (e.g. Templates contains "pages/home.html", not "templates/pages/home.html")
+ * - During development, ASSETS_DIR / -assets-dir can override these with
+ *   os.DirFS(dir) so edits on disk are picked up without a rebuild; see
+ *   Config.AssetFilesystems in cmd/server
+ */
+
+package assets
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:templates
+var embeddedTemplates embed.FS
+
+//go:embed all:public
+var embeddedPublic embed.FS
+
+// Templates is the embedded template filesystem, rooted at "templates".
+var Templates fs.FS = mustSub(embeddedTemplates, "templates")
+
+// Public is the embedded static asset filesystem, rooted at "public".
+var Public fs.FS = mustSub(embeddedPublic, "public")
+
+func mustSub(f embed.FS, dir string) fs.FS {
+	sub, err := fs.Sub(f, dir)
+	if err != nil {
+		// Only reachable if the embed directive above doesn't match dir,
+		// which would be a build-time mistake, not a runtime condition.
+		panic(err)
+	}
+	return sub
+}
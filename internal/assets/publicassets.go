@@ -0,0 +1,95 @@
+/**
+ * @file Fingerprinted static asset serving
+ * @description
+ * Builds a content-hash manifest for a public asset filesystem so templates
+ * can reference cache-busted URLs via {{ asset "css/app.css" }}, resolving to
+ * something like "/static/css/app.a1b2c3d4.css", and serves those URLs back
+ * from the original (unhashed) embedded files with a long-lived
+ * Cache-Control header.
+ */
+
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// PublicAssets serves a public asset filesystem with fingerprinted URLs.
+type PublicAssets struct {
+	fs       fs.FS
+	toHashed map[string]string // original path -> fingerprinted path
+	toOrig   map[string]string // fingerprinted path -> original path
+}
+
+// NewPublicAssets walks publicFS and builds the fingerprint manifest used by
+// URL and FileServer.
+func NewPublicAssets(publicFS fs.FS) (*PublicAssets, error) {
+	p := &PublicAssets{
+		fs:       publicFS,
+		toHashed: map[string]string{},
+		toOrig:   map[string]string{},
+	}
+
+	err := fs.WalkDir(publicFS, ".", func(p2 string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(publicFS, p2)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])[:8]
+
+		ext := path.Ext(p2)
+		base := strings.TrimSuffix(p2, ext)
+		hashed := fmt.Sprintf("%s.%s%s", base, hash, ext)
+
+		p.toHashed[p2] = hashed
+		p.toOrig[hashed] = p2
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building asset manifest: %w", err)
+	}
+
+	return p, nil
+}
+
+// URL returns the fingerprinted "/static/..." URL for a public asset path
+// such as "css/app.css". If the path isn't in the manifest, it's returned
+// unfingerprinted so missing assets fail loudly (404) rather than silently.
+func (p *PublicAssets) URL(assetPath string) string {
+	if hashed, ok := p.toHashed[assetPath]; ok {
+		return "/static/" + hashed
+	}
+	return "/static/" + assetPath
+}
+
+// FileServer serves the public filesystem, resolving fingerprinted URLs back
+// to their original file and attaching a long, immutable Cache-Control
+// header. Non-fingerprinted requests are served as-is with no special
+// caching, since they aren't guaranteed to be immutable.
+func (p *PublicAssets) FileServer() http.Handler {
+	fileServer := http.FileServer(http.FS(p.fs))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqPath := strings.TrimPrefix(r.URL.Path, "/")
+
+		if orig, ok := p.toOrig[reqPath]; ok {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = "/" + orig
+			fileServer.ServeHTTP(w, r2)
+			return
+		}
+
+		fileServer.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,68 @@
+package assets
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func testFS() fs.FS {
+	return fstest.MapFS{
+		"css/app.css": &fstest.MapFile{Data: []byte("body{color:#111}")},
+	}
+}
+
+func TestURLReturnsFingerprintedPath(t *testing.T) {
+	p, err := NewPublicAssets(testFS())
+	if err != nil {
+		t.Fatalf("NewPublicAssets: %v", err)
+	}
+
+	url := p.URL("css/app.css")
+	if url == "/static/css/app.css" {
+		t.Fatalf("expected fingerprinted URL, got unfingerprinted %q", url)
+	}
+	if len(url) <= len("/static/css/app.css") {
+		t.Fatalf("expected fingerprinted URL to be longer than original, got %q", url)
+	}
+}
+
+func TestURLUnknownPathFallsBackUnfingerprinted(t *testing.T) {
+	p, err := NewPublicAssets(testFS())
+	if err != nil {
+		t.Fatalf("NewPublicAssets: %v", err)
+	}
+
+	if got := p.URL("css/missing.css"); got != "/static/css/missing.css" {
+		t.Fatalf("expected unfingerprinted fallback, got %q", got)
+	}
+}
+
+func TestFileServerServesFingerprintedURL(t *testing.T) {
+	p, err := NewPublicAssets(testFS())
+	if err != nil {
+		t.Fatalf("NewPublicAssets: %v", err)
+	}
+
+	fingerprinted := p.URL("css/app.css")
+
+	// Mirrors how cmd/server/main.go mounts the file server: it strips the
+	// "/static/" prefix before FileServer ever sees the request.
+	handler := http.StripPrefix("/static/", p.FileServer())
+
+	req := httptest.NewRequest("GET", fingerprinted, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Body.String(); got != "body{color:#111}" {
+		t.Fatalf("expected original file content, got %q", got)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc == "" {
+		t.Fatalf("expected Cache-Control header on fingerprinted response")
+	}
+}
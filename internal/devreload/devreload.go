@@ -0,0 +1,129 @@
+/**
+ * @file Dev-only live reload
+ * @description
+ * Watches the templates and public directories with fsnotify and broadcasts
+ * a "reload" event to every connected SSE client (see sse.go) whenever they
+ * change, so editing a template or stylesheet during development refreshes
+ * the browser without a manual reload. Only meaningful when assets are
+ * served from disk (ASSETS_DIR set); the embedded production assets can't
+ * change at runtime.
+ *
+ * @dependencies
+ * - github.com/fsnotify/fsnotify: filesystem change notifications
+ *
+ * @notes
+ * - Bursts of events within the debounce window collapse into a single
+ *   broadcast, since editors often touch several files per save
+ */
+
+package devreload
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Reloader tracks connected SSE clients and notifies them of filesystem
+// changes. The zero value is not usable; construct with New.
+type Reloader struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// New returns a Reloader with no connected clients.
+func New() *Reloader {
+	return &Reloader{clients: map[chan struct{}]struct{}{}}
+}
+
+// Watch starts an fsnotify watcher on dirs and every directory beneath them
+// and broadcasts a reload, debounced by window, whenever any of them change.
+// Directories created after Watch returns are not watched. It runs until ctx
+// is cancelled.
+func (rl *Reloader) Watch(ctx context.Context, window time.Duration, dirs ...string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("starting fsnotify watcher: %w", err)
+	}
+
+	for _, dir := range dirs {
+		// fsnotify is not recursive, so each subdirectory is added explicitly
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return watcher.Add(p)
+			}
+			return nil
+		})
+		if err != nil {
+			watcher.Close()
+			return fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+
+	go rl.debounce(watcher.Events, watcher.Errors, window)
+	go func() {
+		<-ctx.Done()
+		watcher.Close()
+	}()
+
+	return nil
+}
+
+// debounce coalesces bursts of filesystem events arriving within window into
+// a single broadcast. It returns once events is closed, which happens when
+// the underlying watcher is closed.
+func (rl *Reloader) debounce(events <-chan fsnotify.Event, errs <-chan error, window time.Duration) {
+	var timer *time.Timer
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(window, rl.broadcast)
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			log.Println("devreload: watcher error:", err)
+		}
+	}
+}
+
+// broadcast notifies every connected SSE client that it should reload.
+func (rl *Reloader) broadcast() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for ch := range rl.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (rl *Reloader) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	rl.mu.Lock()
+	rl.clients[ch] = struct{}{}
+	rl.mu.Unlock()
+	return ch
+}
+
+func (rl *Reloader) unsubscribe(ch chan struct{}) {
+	rl.mu.Lock()
+	delete(rl.clients, ch)
+	rl.mu.Unlock()
+}
@@ -0,0 +1,51 @@
+package devreload
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestDebounceCoalescesBurstIntoOneReload(t *testing.T) {
+	rl := New()
+
+	events := make(chan fsnotify.Event)
+	errs := make(chan error)
+	go rl.debounce(events, errs, 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest("GET", "/_dev/reload", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		rl.ServeSSE(rec, req)
+		close(done)
+	}()
+
+	// Give ServeSSE a moment to subscribe before the burst fires.
+	time.Sleep(10 * time.Millisecond)
+
+	// A burst of saves within the debounce window, as a single editor save
+	// touching multiple files would produce.
+	for i := 0; i < 5; i++ {
+		events <- fsnotify.Event{Name: "base.html", Op: fsnotify.Write}
+	}
+
+	// Wait past the debounce window for the coalesced broadcast to land.
+	time.Sleep(150 * time.Millisecond)
+	close(events)
+	close(errs)
+
+	cancel()
+	<-done
+
+	frames := strings.Count(rec.Body.String(), "event: reload\n")
+	if frames != 1 {
+		t.Fatalf("expected exactly 1 reload frame from the burst, got %d", frames)
+	}
+}
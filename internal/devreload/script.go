@@ -0,0 +1,29 @@
+/**
+ * @file Reload client script
+ * @description
+ * The inline script injected into every page in development that opens an
+ * EventSource to /_dev/reload and reloads the page when it receives a
+ * "reload" event. Takes the page's CSP nonce so it runs under a
+ * script-src policy that doesn't allow 'unsafe-inline'.
+ */
+
+package devreload
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// Script returns the <script> tag that subscribes to the SSE reload stream,
+// carrying nonce so it's allow-listed by the page's CSP. Registered as the
+// "devReloadScript" template func; see cmd/server/main.go.
+func Script(nonce string) template.HTML {
+	return template.HTML(fmt.Sprintf(`<script nonce="%s">
+(function() {
+	var source = new EventSource("/_dev/reload");
+	source.addEventListener("reload", function() {
+		location.reload();
+	});
+})();
+</script>`, template.HTMLEscapeString(nonce)))
+}
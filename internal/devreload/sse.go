@@ -0,0 +1,43 @@
+/**
+ * @file SSE reload endpoint
+ * @description
+ * Exposes ServeSSE, an http.HandlerFunc meant to be mounted at
+ * GET /_dev/reload, which streams a "reload" event to the client every time
+ * Reloader.broadcast fires.
+ */
+
+package devreload
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ServeSSE streams reload events to a single client until the request
+// context is cancelled (e.g. the browser tab closes).
+func (rl *Reloader) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := rl.subscribe()
+	defer rl.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "event: reload\ndata: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/**
+ * @file XML export HTTP handlers
+ * @description
+ * Wires BuildXML up as the planned XML handler: POST /export/xml streams the
+ * current session's selected files as a downloadable XML document, and
+ * GET /export/xml/preview returns the same content wrapped in <pre> for an
+ * HTMX-triggered in-page preview.
+ *
+ * @dependencies
+ * - internal/session: supplies the selected files for the requester
+ */
+
+package xmlexport
+
+import (
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brettsmith212/CodeSync/internal/session"
+)
+
+// Handler serves the XML export and preview routes against root, the
+// filesystem selected file paths are read from.
+type Handler struct {
+	root fs.FS
+}
+
+// NewHandler returns a Handler that reads selected files from root.
+func NewHandler(root fs.FS) *Handler {
+	return &Handler{root: root}
+}
+
+// Export handles POST /export/xml.
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	files := session.GetSelectedFiles(r)
+	if len(files) == 0 {
+		http.Error(w, "no files selected", http.StatusBadRequest)
+		return
+	}
+
+	doc, err := BuildXML(h.root, files, parseOptions(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("codesync-%d.xml", time.Now().Unix())
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write([]byte(doc))
+}
+
+// Preview handles GET /export/xml/preview, an HTMX-triggered partial that
+// renders the same document wrapped in <pre> for in-page display.
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	files := session.GetSelectedFiles(r)
+
+	doc, err := BuildXML(h.root, files, parseOptions(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<pre>%s</pre>", html.EscapeString(doc))
+}
+
+func parseOptions(r *http.Request) Options {
+	r.ParseForm()
+
+	maxBytes, _ := strconv.Atoi(r.FormValue("max_bytes"))
+
+	return Options{
+		IncludeLineNumbers: truthy(r.FormValue("include_line_numbers")),
+		StripComments:      truthy(r.FormValue("strip_comments")),
+		MaxBytes:           maxBytes,
+		Root:               r.FormValue("root"),
+	}
+}
+
+func truthy(v string) bool {
+	return v == "1" || strings.EqualFold(v, "true")
+}
@@ -0,0 +1,89 @@
+/**
+ * @file Language detection and comment stripping
+ * @description
+ * Small extension-to-language table used to annotate exported files, plus a
+ * conservative line-comment stripper used when strip_comments is requested.
+ * Only whole-line comments are stripped (a line whose trimmed content starts
+ * with a comment marker); inline trailing comments are left alone since
+ * naively stripping them risks mangling string literals.
+ */
+
+package xmlexport
+
+import (
+	"path"
+	"strings"
+)
+
+var extToLang = map[string]string{
+	".go":   "go",
+	".js":   "javascript",
+	".jsx":  "javascript",
+	".ts":   "typescript",
+	".tsx":  "typescript",
+	".py":   "python",
+	".rb":   "ruby",
+	".java": "java",
+	".c":    "c",
+	".h":    "c",
+	".cpp":  "cpp",
+	".rs":   "rust",
+	".html": "html",
+	".css":  "css",
+	".json": "json",
+	".md":   "markdown",
+	".sh":   "bash",
+	".yml":  "yaml",
+	".yaml": "yaml",
+	".sql":  "sql",
+}
+
+var lineCommentPrefixes = map[string][]string{
+	"go":         {"//"},
+	"javascript": {"//"},
+	"typescript": {"//"},
+	"java":       {"//"},
+	"c":          {"//"},
+	"cpp":        {"//"},
+	"rust":       {"//"},
+	"python":     {"#"},
+	"ruby":       {"#"},
+	"bash":       {"#"},
+	"yaml":       {"#"},
+}
+
+// detectLang returns a best-effort language name for p based on its
+// extension, or "text" if it isn't recognized.
+func detectLang(p string) string {
+	lang, ok := extToLang[strings.ToLower(path.Ext(p))]
+	if !ok {
+		return "text"
+	}
+	return lang
+}
+
+// stripComments removes whole-line comments for languages with a known
+// single-line comment marker, leaving content unchanged for the rest.
+func stripComments(content, lang string) string {
+	prefixes, ok := lineCommentPrefixes[lang]
+	if !ok {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		isComment := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(trimmed, prefix) {
+				isComment = true
+				break
+			}
+		}
+		if !isComment {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,143 @@
+/**
+ * @file XML codebase export
+ * @description
+ * Builds the deterministic <codebase> XML document described in the XML
+ * export handler: one <file> element per selected path, each carrying its
+ * detected language and a sha256 of its original (untransformed) content,
+ * with the file body wrapped in CDATA. Kept free of net/http so the format
+ * can be unit tested directly against an fs.FS.
+ */
+
+package xmlexport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options controls how each file is rendered into the export.
+type Options struct {
+	IncludeLineNumbers bool
+	StripComments      bool
+	MaxBytes           int    // 0 means no truncation
+	Root               string // if set, strips this prefix from the path shown in the output
+}
+
+// BuildXML renders paths (read from fsys) into the <codebase> XML document.
+// Paths are sorted before rendering so the output is deterministic
+// regardless of selection order.
+func BuildXML(fsys fs.FS, paths []string, opts Options) (string, error) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	b.WriteString("<codebase>\n")
+
+	for _, p := range sorted {
+		if err := writeFile(&b, fsys, p, opts); err != nil {
+			return "", err
+		}
+	}
+
+	b.WriteString("</codebase>\n")
+	return b.String(), nil
+}
+
+func writeFile(b *strings.Builder, fsys fs.FS, p string, opts Options) error {
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", p, err)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	displayPath := rewriteRoot(p, opts.Root)
+	lang := detectLang(p)
+
+	content := string(data)
+	if opts.StripComments {
+		content = stripComments(content, lang)
+	}
+
+	truncated := false
+	originalBytes := len(content)
+	shownBytes := originalBytes
+	if opts.MaxBytes > 0 && len(content) > opts.MaxBytes {
+		content = truncateToRuneBoundary(content, opts.MaxBytes)
+		shownBytes = len(content)
+		truncated = true
+	}
+
+	if opts.IncludeLineNumbers {
+		content = addLineNumbers(content)
+	}
+
+	fmt.Fprintf(b, `<file path="%s" lang="%s" sha256="%s">`, escapeAttr(displayPath), escapeAttr(lang), escapeAttr(hash))
+	b.WriteString("<![CDATA[")
+	b.WriteString(escapeCDATA(content))
+	if truncated {
+		fmt.Fprintf(b, "\n... [truncated, showing first %d of %d bytes] ...", shownBytes, originalBytes)
+	}
+	b.WriteString("]]>")
+	b.WriteString("</file>\n")
+
+	return nil
+}
+
+// escapeCDATA splits any "]]>" sequence in s so it can't prematurely close
+// the CDATA section it's embedded in.
+func escapeCDATA(s string) string {
+	return strings.ReplaceAll(s, "]]>", "]]]]><![CDATA[>")
+}
+
+// escapeAttr escapes s for use inside a double-quoted XML attribute value.
+// Order matters: & must be escaped first so it doesn't double-escape the
+// entities introduced by the later replacements.
+func escapeAttr(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	s = strings.ReplaceAll(s, `"`, "&quot;")
+	return s
+}
+
+// truncateToRuneBoundary returns the longest prefix of s that is no more
+// than maxBytes bytes and ends on a valid rune boundary, so truncation can
+// never split a multi-byte UTF-8 character and leave invalid XML content.
+func truncateToRuneBoundary(s string, maxBytes int) string {
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
+
+// rewriteRoot strips root from p if p is relative to it, for the path shown
+// in the export. Files are still read from fsys under p as given, and fs.FS
+// rejects absolute paths, so an absolute p makes BuildXML fail with a read
+// error rather than come out repo-relative.
+func rewriteRoot(p, root string) string {
+	if root == "" {
+		return p
+	}
+	rel, err := filepath.Rel(root, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return p
+	}
+	return rel
+}
+
+func addLineNumbers(content string) string {
+	lines := strings.Split(content, "\n")
+	width := len(strconv.Itoa(len(lines)))
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("%*d: %s", width, i+1, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,140 @@
+package xmlexport
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"unicode/utf8"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"b.go": &fstest.MapFile{Data: []byte("package b\n")},
+		"a.go": &fstest.MapFile{Data: []byte("package a\n")},
+	}
+}
+
+func TestBuildXMLOrdersFilesDeterministically(t *testing.T) {
+	doc, err := BuildXML(testFS(), []string{"b.go", "a.go"}, Options{})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	aIdx := strings.Index(doc, `path="a.go"`)
+	bIdx := strings.Index(doc, `path="b.go"`)
+	if aIdx == -1 || bIdx == -1 {
+		t.Fatalf("expected both files present, got %q", doc)
+	}
+	if aIdx > bIdx {
+		t.Fatalf("expected a.go before b.go regardless of selection order, got %q", doc)
+	}
+
+	// Re-run with the opposite input order; output must be byte-identical.
+	doc2, err := BuildXML(testFS(), []string{"a.go", "b.go"}, Options{})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+	if doc != doc2 {
+		t.Fatalf("expected deterministic output regardless of input order, got %q vs %q", doc, doc2)
+	}
+}
+
+func TestBuildXMLEscapesCDATATerminator(t *testing.T) {
+	fsys := fstest.MapFS{
+		"weird.go": &fstest.MapFile{Data: []byte("before]]>after")},
+	}
+
+	doc, err := BuildXML(fsys, []string{"weird.go"}, Options{})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	if strings.Contains(doc, "before]]>after") {
+		t.Fatalf("expected ]]> to be escaped, got %q", doc)
+	}
+	if !strings.Contains(doc, "before]]]]><![CDATA[>after") {
+		t.Fatalf("expected escaped CDATA terminator, got %q", doc)
+	}
+}
+
+func TestBuildXMLTruncatesToMaxBytes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"big.go": &fstest.MapFile{Data: []byte("0123456789")},
+	}
+
+	doc, err := BuildXML(fsys, []string{"big.go"}, Options{MaxBytes: 4})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	if !strings.Contains(doc, "0123") {
+		t.Fatalf("expected truncated content to start with first 4 bytes, got %q", doc)
+	}
+	if strings.Contains(doc, "0123456789") {
+		t.Fatalf("expected content to be truncated, got full content in %q", doc)
+	}
+	if !strings.Contains(doc, "truncated, showing first 4 of 10 bytes") {
+		t.Fatalf("expected truncation marker, got %q", doc)
+	}
+}
+
+func TestBuildXMLEscapesAttributeSpecialChars(t *testing.T) {
+	fsys := fstest.MapFS{
+		`weird"name.go`: &fstest.MapFile{Data: []byte("package weird\n")},
+	}
+
+	doc, err := BuildXML(fsys, []string{`weird"name.go`}, Options{})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	if !strings.Contains(doc, `path="weird&quot;name.go"`) {
+		t.Fatalf("expected escaped quote in path attribute, got %q", doc)
+	}
+	if strings.Contains(doc, `weird"name.go"`) {
+		t.Fatalf("expected raw quote not to appear unescaped, got %q", doc)
+	}
+}
+
+func TestBuildXMLTruncatesOnRuneBoundary(t *testing.T) {
+	fsys := fstest.MapFS{
+		"cjk.go": &fstest.MapFile{Data: []byte("你好")}, // 3 bytes per rune
+	}
+
+	doc, err := BuildXML(fsys, []string{"cjk.go"}, Options{MaxBytes: 4})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	start := strings.Index(doc, "<![CDATA[") + len("<![CDATA[")
+	end := strings.Index(doc, "\n... [truncated")
+	if start == -1 || end == -1 || end < start {
+		t.Fatalf("expected truncation marker in output, got %q", doc)
+	}
+
+	body := doc[start:end]
+	if !utf8.ValidString(body) {
+		t.Fatalf("expected truncated content to be valid UTF-8, got %q", body)
+	}
+	if body != "你" {
+		t.Fatalf("expected truncation to stop at the last whole rune, got %q", body)
+	}
+}
+
+func TestBuildXMLIncludesHashAndLang(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.py": &fstest.MapFile{Data: []byte("print('hi')\n")},
+	}
+
+	doc, err := BuildXML(fsys, []string{"main.py"}, Options{})
+	if err != nil {
+		t.Fatalf("BuildXML: %v", err)
+	}
+
+	if !strings.Contains(doc, `lang="python"`) {
+		t.Fatalf("expected detected language python, got %q", doc)
+	}
+	if !strings.Contains(doc, `sha256="`) {
+		t.Fatalf("expected a sha256 attribute, got %q", doc)
+	}
+}
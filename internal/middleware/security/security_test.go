@@ -0,0 +1,71 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestHandler(cfg Config) http.Handler {
+	return New(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Nonce(r)))
+	}))
+}
+
+func TestHeadersSetOnResponse(t *testing.T) {
+	h := newTestHandler(DefaultConfig())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	for _, header := range []string{
+		"Content-Security-Policy",
+		"X-Content-Type-Options",
+		"Referrer-Policy",
+		"Permissions-Policy",
+	} {
+		if rec.Header().Get(header) == "" {
+			t.Errorf("expected %s header to be set", header)
+		}
+	}
+
+	if rec.Header().Get("Strict-Transport-Security") != "" {
+		t.Errorf("expected no HSTS header when HSTSMaxAgeSeconds is 0")
+	}
+}
+
+func TestHSTSEmittedWhenConfigured(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HSTSMaxAgeSeconds = 63072000
+
+	h := newTestHandler(cfg)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if rec.Header().Get("Strict-Transport-Security") == "" {
+		t.Errorf("expected HSTS header when HSTSMaxAgeSeconds is set")
+	}
+}
+
+func TestNonceChangesPerRequest(t *testing.T) {
+	h := newTestHandler(DefaultConfig())
+
+	rec1 := httptest.NewRecorder()
+	h.ServeHTTP(rec1, httptest.NewRequest("GET", "/", nil))
+
+	rec2 := httptest.NewRecorder()
+	h.ServeHTTP(rec2, httptest.NewRequest("GET", "/", nil))
+
+	nonce1, nonce2 := rec1.Body.String(), rec2.Body.String()
+	if nonce1 == "" || nonce2 == "" {
+		t.Fatalf("expected non-empty nonces, got %q and %q", nonce1, nonce2)
+	}
+	if nonce1 == nonce2 {
+		t.Fatalf("expected nonce to change per request, got the same value twice: %q", nonce1)
+	}
+
+	if csp := rec1.Header().Get("Content-Security-Policy"); !strings.Contains(csp, nonce1) {
+		t.Errorf("expected CSP header to contain the request's nonce, got %q", csp)
+	}
+}
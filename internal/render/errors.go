@@ -0,0 +1,32 @@
+/**
+ * @file Render error fallback
+ * @description
+ * Best-effort error page rendering for handlers that fail mid-request. Falls
+ * back to plain text if the "error" page itself can't be rendered, so a
+ * broken error template never masks the original failure.
+ */
+
+package render
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/brettsmith212/CodeSync/internal/middleware/security"
+)
+
+// ServerError logs err and renders the "error" page with a 500 status,
+// falling back to http.Error if that page can't be rendered either.
+func ServerError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Println(err)
+
+	data := map[string]any{
+		"Error":    err.Error(),
+		"CSPNonce": security.Nonce(r),
+	}
+
+	w.WriteHeader(http.StatusInternalServerError)
+	if pageErr := Page(w, r, "error", data); pageErr != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
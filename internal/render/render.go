@@ -0,0 +1,152 @@
+/**
+ * @file Template rendering
+ * @description
+ * Builds and serves the page template cache used by every handler. Pages
+ * live in a "pages" directory within the configured filesystem, each
+ * composed with the shared layout in "layouts/base.html" and any partials in
+ * "partials", mirroring the AppConfig-driven template cache pattern common to
+ * mature Go web apps. The filesystem itself is caller-supplied so both the
+ * embedded assets.Templates FS and an os.DirFS override work unchanged.
+ *
+ * In production (APP_ENV=production) the cache is built once at boot via
+ * NewAppConfig and reused for every request. In development it is rebuilt on
+ * every call to Page so template edits show up without a restart.
+ *
+ * @notes
+ * - Page names are looked up without their .html extension, e.g. "home" for
+ *   pages/home.html
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+)
+
+// AppConfig holds the current render configuration, shared by every call to
+// Page.
+type AppConfig struct {
+	TemplatesFS   fs.FS
+	FuncMap       template.FuncMap
+	UseCache      bool
+	TemplateCache map[string]*template.Template
+}
+
+var app *AppConfig
+
+// NewAppConfig builds the render package's AppConfig for templatesFS. In
+// production mode the template cache is parsed once up front; otherwise it is
+// left empty and rebuilt on every Page call. funcMap is registered on every
+// page template and may be nil.
+func NewAppConfig(templatesFS fs.FS, funcMap template.FuncMap, production bool) (*AppConfig, error) {
+	cfg := &AppConfig{
+		TemplatesFS: templatesFS,
+		FuncMap:     funcMap,
+		UseCache:    production,
+	}
+
+	if production {
+		tc, err := NewTemplateCache(templatesFS, funcMap)
+		if err != nil {
+			return nil, fmt.Errorf("building template cache: %w", err)
+		}
+		cfg.TemplateCache = tc
+	}
+
+	app = cfg
+	return cfg, nil
+}
+
+// TemplateNotFoundError is returned by Page when name has no matching entry
+// in the template cache.
+type TemplateNotFoundError struct {
+	Name string
+}
+
+func (e *TemplateNotFoundError) Error() string {
+	return fmt.Sprintf("render: template %q not found in cache", e.Name)
+}
+
+// NewTemplateCache parses every page under "pages" in templatesFS, composing
+// each with the shared base layout and any partials, and returns them keyed
+// by page name (without the .html extension).
+func NewTemplateCache(templatesFS fs.FS, funcMap template.FuncMap) (map[string]*template.Template, error) {
+	cache := map[string]*template.Template{}
+
+	pages, err := fs.Glob(templatesFS, "pages/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := fs.Glob(templatesFS, "partials/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	const layout = "layouts/base.html"
+	hasLayout := true
+	if _, err := fs.Stat(templatesFS, layout); err != nil {
+		hasLayout = false
+	}
+
+	for _, page := range pages {
+		name := path.Base(page)
+		ts, err := template.New(name).Funcs(funcMap).ParseFS(templatesFS, page)
+		if err != nil {
+			return nil, fmt.Errorf("parsing page %s: %w", name, err)
+		}
+
+		if len(partials) > 0 {
+			ts, err = ts.ParseFS(templatesFS, partials...)
+			if err != nil {
+				return nil, fmt.Errorf("parsing partials for %s: %w", name, err)
+			}
+		}
+
+		if hasLayout {
+			ts, err = ts.ParseFS(templatesFS, layout)
+			if err != nil {
+				return nil, fmt.Errorf("parsing layout for %s: %w", name, err)
+			}
+		}
+
+		cache[trimExt(name)] = ts
+	}
+
+	return cache, nil
+}
+
+// Page renders the named page to w. In development mode it reparses the
+// template cache first so edits are visible without a restart.
+func Page(w http.ResponseWriter, r *http.Request, name string, data any) error {
+	tc := app.TemplateCache
+	if !app.UseCache {
+		var err error
+		tc, err = NewTemplateCache(app.TemplatesFS, app.FuncMap)
+		if err != nil {
+			return fmt.Errorf("rebuilding template cache: %w", err)
+		}
+	}
+
+	ts, ok := tc[name]
+	if !ok {
+		return &TemplateNotFoundError{Name: name}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ts.ExecuteTemplate(buf, "base", data); err != nil {
+		return fmt.Errorf("executing template %q: %w", name, err)
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func trimExt(name string) string {
+	return name[:len(name)-len(path.Ext(name))]
+}
@@ -0,0 +1,101 @@
+package render
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestTemplates builds a minimal pages/layouts/partials tree for tests.
+func writeTestTemplates(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, sub := range []string{"pages", "layouts", "partials"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", sub, err)
+		}
+	}
+
+	base := `{{define "base"}}<html>{{template "nav" .}}{{block "content" .}}{{end}}</html>{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "layouts", "base.html"), []byte(base), 0o644); err != nil {
+		t.Fatalf("write base.html: %v", err)
+	}
+
+	nav := `{{define "nav"}}<nav>nav</nav>{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "partials", "nav.html"), []byte(nav), 0o644); err != nil {
+		t.Fatalf("write nav.html: %v", err)
+	}
+
+	home := `{{define "content"}}home:{{.Title}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "pages", "home.html"), []byte(home), 0o644); err != nil {
+		t.Fatalf("write home.html: %v", err)
+	}
+
+	return dir
+}
+
+func TestPageUsesCacheInProduction(t *testing.T) {
+	dir := writeTestTemplates(t)
+
+	if _, err := NewAppConfig(os.DirFS(dir), nil, true); err != nil {
+		t.Fatalf("NewAppConfig: %v", err)
+	}
+
+	// Changing a page after the cache is built should not affect output,
+	// since production mode parses once at boot.
+	if err := os.WriteFile(filepath.Join(dir, "pages", "home.html"), []byte(`{{define "content"}}changed{{end}}`), 0o644); err != nil {
+		t.Fatalf("rewrite home.html: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := Page(rec, req, "home", map[string]any{"Title": "Home"}); err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+
+	if got := rec.Body.String(); got != "<html><nav>nav</nav>home:Home</html>" {
+		t.Fatalf("expected cached output unaffected by edit, got %q", got)
+	}
+}
+
+func TestPageReparsesInDevelopment(t *testing.T) {
+	dir := writeTestTemplates(t)
+
+	if _, err := NewAppConfig(os.DirFS(dir), nil, false); err != nil {
+		t.Fatalf("NewAppConfig: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "pages", "home.html"), []byte(`{{define "content"}}changed{{end}}`), 0o644); err != nil {
+		t.Fatalf("rewrite home.html: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := Page(rec, req, "home", map[string]any{"Title": "Home"}); err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+
+	if got := rec.Body.String(); got != "<html><nav>nav</nav>changed</html>" {
+		t.Fatalf("expected reparsed output to reflect edit, got %q", got)
+	}
+}
+
+func TestPageMissingTemplateReturnsTypedError(t *testing.T) {
+	dir := writeTestTemplates(t)
+
+	if _, err := NewAppConfig(os.DirFS(dir), nil, true); err != nil {
+		t.Fatalf("NewAppConfig: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	err := Page(rec, req, "does-not-exist", nil)
+
+	var notFound *TemplateNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected TemplateNotFoundError, got %v", err)
+	}
+}
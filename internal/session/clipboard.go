@@ -0,0 +1,52 @@
+/**
+ * @file Clipboard/selection helpers
+ * @description
+ * Session-backed helpers for the multi-file selection a user builds up via
+ * HTMX interactions before exporting it (see the planned XML export
+ * handler). Backed by the package's default Manager, set by session.New.
+ */
+
+package session
+
+import "net/http"
+
+const selectedFilesKey = "selectedFiles"
+
+// GetSelectedFiles returns the files currently in the clipboard for the
+// requester's session, or nil if none have been selected yet.
+func GetSelectedFiles(r *http.Request) []string {
+	v := defaultManager.Get(r.Context(), selectedFilesKey)
+	files, ok := v.([]string)
+	if !ok {
+		return nil
+	}
+	return files
+}
+
+// AddFile adds path to the session's clipboard if it isn't already present.
+func AddFile(r *http.Request, path string) {
+	files := GetSelectedFiles(r)
+	for _, f := range files {
+		if f == path {
+			return
+		}
+	}
+	defaultManager.Put(r.Context(), selectedFilesKey, append(files, path))
+}
+
+// RemoveFile removes path from the session's clipboard, if present.
+func RemoveFile(r *http.Request, path string) {
+	files := GetSelectedFiles(r)
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		if f != path {
+			out = append(out, f)
+		}
+	}
+	defaultManager.Put(r.Context(), selectedFilesKey, out)
+}
+
+// ClearClipboard empties the session's clipboard entirely.
+func ClearClipboard(r *http.Request) {
+	defaultManager.Remove(r.Context(), selectedFilesKey)
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer() *httptest.Server {
+	mgr := New(nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/add", func(w http.ResponseWriter, r *http.Request) {
+		AddFile(r, r.URL.Query().Get("path"))
+	})
+	mux.HandleFunc("/remove", func(w http.ResponseWriter, r *http.Request) {
+		RemoveFile(r, r.URL.Query().Get("path"))
+	})
+	mux.HandleFunc("/files", func(w http.ResponseWriter, r *http.Request) {
+		for _, f := range GetSelectedFiles(r) {
+			w.Write([]byte(f + "\n"))
+		}
+	})
+	mux.HandleFunc("/clear", func(w http.ResponseWriter, r *http.Request) {
+		ClearClipboard(r)
+	})
+
+	return httptest.NewServer(mgr.LoadAndSave(mux))
+}
+
+func TestClipboardSurvivesAcrossRequests(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("new cookie jar: %v", err)
+	}
+	client := &http.Client{Jar: jar}
+
+	mustGet := func(path string) string {
+		t.Helper()
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		buf := make([]byte, 4096)
+		n, _ := resp.Body.Read(buf)
+		return string(buf[:n])
+	}
+
+	mustGet("/add?path=a.go")
+	mustGet("/add?path=b.go")
+
+	got := mustGet("/files")
+	if got != "a.go\nb.go\n" {
+		t.Fatalf("expected a.go and b.go selected, got %q", got)
+	}
+
+	mustGet("/remove?path=a.go")
+	got = mustGet("/files")
+	if got != "b.go\n" {
+		t.Fatalf("expected only b.go selected, got %q", got)
+	}
+
+	mustGet("/clear")
+	got = mustGet("/files")
+	if got != "" {
+		t.Fatalf("expected empty clipboard after clear, got %q", got)
+	}
+}
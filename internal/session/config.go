@@ -0,0 +1,79 @@
+/**
+ * @file Session configuration
+ * @description
+ * Loads cookie and lifetime settings for the scs session manager from
+ * environment variables, with defaults suitable for local development.
+ */
+
+package session
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config controls the cookie and lifetime behavior of the session manager.
+type Config struct {
+	CookieName string
+	Lifetime   time.Duration
+	SameSite   http.SameSite
+	Secure     bool
+}
+
+// loadConfig builds a Config from SESSION_* environment variables, falling
+// back to defaults for anything unset or invalid.
+func loadConfig() *Config {
+	return &Config{
+		CookieName: getEnv("SESSION_COOKIE_NAME", "codesync_session"),
+		Lifetime:   getEnvHours("SESSION_LIFETIME_HOURS", 24*time.Hour),
+		SameSite:   getEnvSameSite("SESSION_SAME_SITE", http.SameSiteLaxMode),
+		Secure:     getEnvBool("SESSION_SECURE", false),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvHours(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	hours, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+func getEnvSameSite(key string, fallback http.SameSite) http.SameSite {
+	switch strings.ToLower(os.Getenv(key)) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,62 @@
+/**
+ * @file Session manager
+ * @description
+ * Wires up an alexedwards/scs session manager used to hold per-visitor state
+ * (currently just the clipboard file selection) across HTMX requests without
+ * a database. Defaults to scs's in-memory store; pass a different scs.Store
+ * implementation (Redis, SQLite, ...) to New for production deployments.
+ *
+ * @dependencies
+ * - github.com/alexedwards/scs/v2: session middleware and store
+ *
+ * @notes
+ * - Application types stored in the session must be registered with gob in
+ *   init() below so they survive the store's encode/decode round trip.
+ */
+
+package session
+
+import (
+	"encoding/gob"
+	"net/http"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+func init() {
+	gob.Register([]string{})
+}
+
+// Manager wraps scs.SessionManager with CodeSync's configuration and the
+// clipboard helpers in clipboard.go.
+type Manager struct {
+	*scs.SessionManager
+}
+
+// defaultManager backs the package-level clipboard helpers, set by New.
+var defaultManager *Manager
+
+// New builds a Manager configured from SESSION_* environment variables. If
+// store is nil, scs's default in-memory store is used.
+func New(store scs.Store) *Manager {
+	cfg := loadConfig()
+
+	sm := scs.New()
+	sm.Lifetime = cfg.Lifetime
+	sm.Cookie.Name = cfg.CookieName
+	sm.Cookie.SameSite = cfg.SameSite
+	sm.Cookie.Secure = cfg.Secure
+	if store != nil {
+		sm.Store = store
+	}
+
+	mgr := &Manager{SessionManager: sm}
+	defaultManager = mgr
+	return mgr
+}
+
+// LoadAndSave is chi-compatible middleware that loads the session for the
+// request and saves any changes before the response is written.
+func (m *Manager) LoadAndSave(next http.Handler) http.Handler {
+	return m.SessionManager.LoadAndSave(next)
+}